@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"fmt"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -19,20 +20,34 @@ func (d *DiscoveryService) discoverFromOrganizations(cfg *OrganizationsDiscovery
 		return nil, fmt.Errorf("no access to Organizations API from this execution context")
 	}
 
+	ou := cfg.OU
+	if strings.HasPrefix(ou, "/") {
+		resolvedOU, err := d.resolveOUPath(ou)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve OU path %q: %w", ou, err)
+		}
+		l = l.WithField("resolvedOU", resolvedOU)
+		ou = resolvedOU
+	}
+
 	var accounts []AccountInfo
 
 	// Discover by OU
-	if cfg.OU != "" {
+	if ou != "" {
 		if cfg.Recursive {
 			// Recursive traversal of OU and all child OUs
-			ouAccounts, err := d.listAccountsInOURecursive(cfg.OU)
+			ouAccounts, stats, err := d.listAccountsInOURecursive(ou, cfg)
 			if err != nil {
 				return nil, err
 			}
 			accounts = append(accounts, ouAccounts...)
+			l = l.WithFields(log.Fields{
+				"depthReached": stats.depthReached,
+				"prunedOUs":    stats.prunedOUs,
+			})
 		} else {
 			// Direct children only
-			ouAccounts, err := d.awsCtx.ListAccountsInOU(d.ctx, cfg.OU)
+			ouAccounts, err := d.awsCtx.ListAccountsInOU(d.ctx, ou)
 			if err != nil {
 				return nil, err
 			}
@@ -40,8 +55,9 @@ func (d *DiscoveryService) discoverFromOrganizations(cfg *OrganizationsDiscovery
 		}
 	}
 
-	// If no OU specified but tags are specified, list all accounts and filter
-	if cfg.OU == "" && len(cfg.Tags) > 0 {
+	// If no OU specified but a tag filter is specified, list all accounts
+	// and filter below.
+	if ou == "" && (len(cfg.Tags) > 0 || cfg.TagExpression != "") {
 		allAccounts, err := d.awsCtx.ListOrganizationAccounts(d.ctx)
 		if err != nil {
 			return nil, err
@@ -49,42 +65,80 @@ func (d *DiscoveryService) discoverFromOrganizations(cfg *OrganizationsDiscovery
 		accounts = append(accounts, allAccounts...)
 	}
 
-	// Filter by tags if specified
-	if len(cfg.Tags) > 0 {
+	// Prefer the boolean TagExpression over the plain Tags map when both
+	// are set; Tags is kept only for backward compatibility with existing
+	// configs.
+	if cfg.TagExpression != "" {
+		expr, err := ParseTagExpression(cfg.TagExpression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag expression: %w", err)
+		}
+		accounts = filterAccountsByTagExpression(accounts, expr)
+	} else if len(cfg.Tags) > 0 {
 		accounts = filterAccountsByTags(accounts, cfg.Tags)
 	}
 
+	// Suspended/pending-closure accounts silently failing later assume-role
+	// calls is a common footgun, so by default only ACTIVE accounts pass.
+	beforeStatusFilter := len(accounts)
+	accounts = filterAccountsByStatus(accounts, cfg.IncludeStatuses, cfg.ExcludeStatuses)
+	l.WithField("excludedByStatus", beforeStatusFilter-len(accounts)).Debug("Filtered accounts by status")
+
 	l.WithField("count", len(accounts)).Debug("Discovered accounts from Organizations")
 	return accounts, nil
 }
 
-// listAccountsInOURecursive recursively lists accounts in an OU and all child OUs
-func (d *DiscoveryService) listAccountsInOURecursive(ouID string) ([]AccountInfo, error) {
-	var accounts []AccountInfo
+// defaultIncludeStatuses is used when cfg.IncludeStatuses is unset: only
+// ACTIVE accounts are safe to act on by default, since SUSPENDED and
+// PENDING_CLOSURE accounts typically fail later assume-role calls.
+var defaultIncludeStatuses = []string{"ACTIVE"}
 
-	// Get accounts directly in this OU
-	ouAccounts, err := d.awsCtx.ListAccountsInOU(d.ctx, ouID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list accounts in OU %s: %w", ouID, err)
-	}
-	accounts = append(accounts, ouAccounts...)
-
-	// Get child OUs and recurse
-	childOUs, err := d.awsCtx.ListChildOUs(d.ctx, ouID)
-	if err != nil {
-		// Log but continue - we might not have permission to list child OUs
-		log.WithError(err).WithField("ou", ouID).Debug("Could not list child OUs")
-		return accounts, nil
+// filterAccountsByStatus keeps only accounts whose Status is in include (or
+// in defaultIncludeStatuses when include is empty) and not in exclude.
+// exclude is applied after include, so it can carve exceptions out of an
+// explicit include list.
+func filterAccountsByStatus(accounts []AccountInfo, include, exclude []string) []AccountInfo {
+	includeSet := toStatusSet(include)
+	if len(includeSet) == 0 {
+		includeSet = toStatusSet(defaultIncludeStatuses)
 	}
+	excludeSet := toStatusSet(exclude)
 
-	for _, childOU := range childOUs {
-		childAccounts, err := d.listAccountsInOURecursive(childOU)
-		if err != nil {
-			log.WithError(err).WithField("childOU", childOU).Debug("Error recursing into child OU")
+	var filtered []AccountInfo
+	for _, acct := range accounts {
+		if _, ok := includeSet[acct.Status]; !ok {
 			continue
 		}
-		accounts = append(accounts, childAccounts...)
+		if _, ok := excludeSet[acct.Status]; ok {
+			continue
+		}
+		filtered = append(filtered, acct)
 	}
+	return filtered
+}
 
-	return accounts, nil
+func toStatusSet(statuses []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(statuses))
+	for _, s := range statuses {
+		set[s] = struct{}{}
+	}
+	return set
+}
+
+// ouTraversalStats summarizes a recursive OU walk for logging: how deep it
+// actually went (bounded by cfg.MaxDepth) and how many OUs were pruned,
+// either because they were excluded or had already been visited.
+type ouTraversalStats struct {
+	depthReached int
+	prunedOUs    int
+}
+
+// listAccountsInOURecursive walks ouID and all of its descendant OUs,
+// honoring cfg.MaxDepth (0 = unlimited, 1 = direct children only, ...) and
+// cfg.ExcludeOUs. The walk is dispatched onto a bounded worker pool (see
+// ou_traversal.go) so large orgs don't pay for a strictly serial traversal;
+// a visited-set still guards against cycles and duplicate entries the
+// Organizations API can return.
+func (d *DiscoveryService) listAccountsInOURecursive(ouID string, cfg *OrganizationsDiscovery) ([]AccountInfo, *ouTraversalStats, error) {
+	return d.newOUWalker(cfg).run(ouID, "")
 }
@@ -0,0 +1,41 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/extended-data-library/secretssync/pkg/pipeline/orggraph"
+)
+
+// DiscoverOrgGraph walks cfg's OU through the same bounded worker pool,
+// rate limiter and cancellation handling as discoverFromOrganizations (see
+// ou_traversal.go), but instead of flattening the result into
+// []AccountInfo it records the Organization/OU/Account/Tag structure as an
+// orggraph.Graph. Downstream stages can then answer structural queries
+// ("all accounts under any OU tagged tier=0") against the graph without
+// re-querying AWS.
+func (d *DiscoveryService) DiscoverOrgGraph(cfg *OrganizationsDiscovery) (*orggraph.Graph, error) {
+	if !d.awsCtx.CanAccessOrganizations() {
+		return nil, fmt.Errorf("no access to Organizations API from this execution context")
+	}
+
+	ou := cfg.OU
+	if strings.HasPrefix(ou, "/") {
+		resolvedOU, err := d.resolveOUPath(ou)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve OU path %q: %w", ou, err)
+		}
+		ou = resolvedOU
+	}
+	if ou == "" {
+		return nil, fmt.Errorf("DiscoverOrgGraph requires cfg.OU to be set")
+	}
+
+	g := orggraph.NewGraph()
+	g.AddNode(orggraph.Node{ID: orggraph.OrgNodeID(), Type: orggraph.NodeOrganization})
+
+	if _, _, err := d.newOUGraphWalker(cfg, g).run(ou, orggraph.OrgNodeID()); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
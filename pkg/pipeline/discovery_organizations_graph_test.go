@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDiscoverOrgGraphRecordsStructure(t *testing.T) {
+	tree := newTestOrgTree()
+	tree.accounts["a1"] = []AccountInfo{{ID: "a1-acct", Name: "a1-acct", Tags: map[string]string{"Environment": "prod"}}}
+
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: tree}
+	g, err := d.DiscoverOrgGraph(&OrganizationsDiscovery{OU: "root"})
+	if err != nil {
+		t.Fatalf("DiscoverOrgGraph: %v", err)
+	}
+
+	wantNodeIDs := []string{}
+	for _, ou := range []string{"root", "a", "a1", "a2", "b"} {
+		wantNodeIDs = append(wantNodeIDs, ouGraphNodeID(ou))
+	}
+
+	nodeSet := make(map[string]bool)
+	for _, n := range g.Nodes {
+		nodeSet[n.ID] = true
+	}
+	for _, id := range wantNodeIDs {
+		if !nodeSet[id] {
+			t.Fatalf("expected OU node %q in graph, nodes: %+v", id, g.Nodes)
+		}
+	}
+
+	foundTaggedEdge := false
+	for _, e := range g.Edges {
+		if e.Type == "TAGGED" {
+			foundTaggedEdge = true
+		}
+	}
+	if !foundTaggedEdge {
+		t.Fatalf("expected at least one TAGGED edge from the a1 account's tags")
+	}
+}
+
+// ouGraphNodeID mirrors orggraph.OUNodeID without importing the subpackage,
+// to keep this test focused on pipeline-level behavior.
+func ouGraphNodeID(ouID string) string {
+	return "ou:" + ouID
+}
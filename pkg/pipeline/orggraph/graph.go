@@ -0,0 +1,106 @@
+// Package orggraph models a discovered AWS Organizations tree as a typed
+// graph (nodes for Organization, OrganizationalUnit, Account and Tag,
+// edges for CONTAINS and TAGGED relationships) so downstream pipeline
+// stages can query structure ("all accounts under any OU tagged tier=0")
+// without re-querying AWS.
+package orggraph
+
+import "fmt"
+
+// NodeType identifies what an org-tree entity a Node represents.
+type NodeType string
+
+const (
+	NodeOrganization NodeType = "Organization"
+	NodeOU           NodeType = "OrganizationalUnit"
+	NodeAccount      NodeType = "Account"
+	NodeTag          NodeType = "Tag"
+)
+
+// EdgeType identifies the relationship an Edge represents.
+type EdgeType string
+
+const (
+	// EdgeContains links an Organization/OU to the OU or Account it
+	// directly contains.
+	EdgeContains EdgeType = "CONTAINS"
+	// EdgeTagged links an Account or OU to a Tag applied to it.
+	EdgeTagged EdgeType = "TAGGED"
+)
+
+// Node is a single entity in the graph. Properties carries entity-specific
+// metadata (e.g. an account's name, a tag's key/value).
+type Node struct {
+	ID         string            `json:"id"`
+	Type       NodeType          `json:"type"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Edge is a directed relationship between two nodes, identified by ID.
+type Edge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Type EdgeType `json:"type"`
+}
+
+// Graph is a discovered Organizations tree. Nodes are deduplicated by ID,
+// so the same Tag or Account encountered via multiple OUs is represented
+// once with multiple incoming edges.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+
+	nodeIndex map[string]int
+}
+
+// NewGraph returns an empty Graph ready for AddNode/AddEdge.
+func NewGraph() *Graph {
+	return &Graph{nodeIndex: make(map[string]int)}
+}
+
+// AddNode inserts node, or merges its Properties into the existing node of
+// the same ID if one was already added.
+func (g *Graph) AddNode(node Node) {
+	if g.nodeIndex == nil {
+		g.nodeIndex = make(map[string]int)
+	}
+	if idx, ok := g.nodeIndex[node.ID]; ok {
+		for k, v := range node.Properties {
+			if g.Nodes[idx].Properties == nil {
+				g.Nodes[idx].Properties = make(map[string]string)
+			}
+			g.Nodes[idx].Properties[k] = v
+		}
+		return
+	}
+	g.nodeIndex[node.ID] = len(g.Nodes)
+	g.Nodes = append(g.Nodes, node)
+}
+
+// AddEdge appends edge. Edges are not deduplicated: adding the same
+// relationship twice records it twice.
+func (g *Graph) AddEdge(edge Edge) {
+	g.Edges = append(g.Edges, edge)
+}
+
+// OrgNodeID returns the (single) Organization node's ID.
+func OrgNodeID() string {
+	return "org:root"
+}
+
+// OUNodeID returns the node ID for the OU with the given AWS OU ID.
+func OUNodeID(ouID string) string {
+	return fmt.Sprintf("ou:%s", ouID)
+}
+
+// AccountNodeID returns the node ID for the account with the given AWS
+// account ID.
+func AccountNodeID(accountID string) string {
+	return fmt.Sprintf("account:%s", accountID)
+}
+
+// TagNodeID returns the node ID for a key/value tag, shared by every
+// account or OU tagged with that exact pair.
+func TagNodeID(key, value string) string {
+	return fmt.Sprintf("tag:%s=%s", key, value)
+}
@@ -0,0 +1,48 @@
+package orggraph
+
+import "testing"
+
+func TestAddNodeDedupesByIDAndMergesProperties(t *testing.T) {
+	g := NewGraph()
+	g.AddNode(Node{ID: "account:111", Type: NodeAccount, Properties: map[string]string{"name": "a"}})
+	g.AddNode(Node{ID: "account:111", Type: NodeAccount, Properties: map[string]string{"status": "ACTIVE"}})
+
+	if len(g.Nodes) != 1 {
+		t.Fatalf("len(g.Nodes) = %d, want 1 (duplicate ID must be merged, not appended)", len(g.Nodes))
+	}
+	props := g.Nodes[0].Properties
+	if props["name"] != "a" || props["status"] != "ACTIVE" {
+		t.Fatalf("merged properties = %v, want name=a status=ACTIVE", props)
+	}
+}
+
+func TestAddEdgeDoesNotDedupe(t *testing.T) {
+	g := NewGraph()
+	edge := Edge{From: "ou:a", To: "account:1", Type: EdgeContains}
+	g.AddEdge(edge)
+	g.AddEdge(edge)
+
+	if len(g.Edges) != 2 {
+		t.Fatalf("len(g.Edges) = %d, want 2 (edges are not deduplicated)", len(g.Edges))
+	}
+}
+
+func TestNodeIDHelpersAreStableAndDistinct(t *testing.T) {
+	ids := []string{
+		OrgNodeID(),
+		OUNodeID("ou-1"),
+		AccountNodeID("111111111111"),
+		TagNodeID("Environment", "prod"),
+	}
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("node ID helpers collided on %q", id)
+		}
+		seen[id] = true
+	}
+
+	if TagNodeID("Environment", "prod") != TagNodeID("Environment", "prod") {
+		t.Fatalf("TagNodeID must be stable for the same key/value pair")
+	}
+}
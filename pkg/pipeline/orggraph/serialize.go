@@ -0,0 +1,112 @@
+package orggraph
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ToJSON renders the graph as indented JSON.
+func (g *Graph) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// WriteNeo4jCSV writes the graph as a Neo4j-compatible pair of CSVs
+// suitable for `neo4j-admin import` or `LOAD CSV`: nodes get an
+// id/label/properties header, edges get a start/end/type header. Property
+// maps are flattened into a single semicolon-separated "k=v;k=v" column
+// since node property keys vary by type.
+func (g *Graph) WriteNeo4jCSV(nodesW, edgesW io.Writer) error {
+	nodeWriter := csv.NewWriter(nodesW)
+	if err := nodeWriter.Write([]string{"id:ID", "label", "properties"}); err != nil {
+		return fmt.Errorf("writing nodes.csv header: %w", err)
+	}
+	for _, n := range g.Nodes {
+		if err := nodeWriter.Write([]string{n.ID, string(n.Type), flattenProperties(n.Properties)}); err != nil {
+			return fmt.Errorf("writing node %s: %w", n.ID, err)
+		}
+	}
+	nodeWriter.Flush()
+	if err := nodeWriter.Error(); err != nil {
+		return fmt.Errorf("flushing nodes.csv: %w", err)
+	}
+
+	edgeWriter := csv.NewWriter(edgesW)
+	if err := edgeWriter.Write([]string{":START_ID", ":END_ID", ":TYPE"}); err != nil {
+		return fmt.Errorf("writing edges.csv header: %w", err)
+	}
+	for _, e := range g.Edges {
+		if err := edgeWriter.Write([]string{e.From, e.To, string(e.Type)}); err != nil {
+			return fmt.Errorf("writing edge %s->%s: %w", e.From, e.To, err)
+		}
+	}
+	edgeWriter.Flush()
+	if err := edgeWriter.Error(); err != nil {
+		return fmt.Errorf("flushing edges.csv: %w", err)
+	}
+
+	return nil
+}
+
+func flattenProperties(props map[string]string) string {
+	if len(props) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := ""
+	for i, k := range keys {
+		if i > 0 {
+			out += ";"
+		}
+		out += fmt.Sprintf("%s=%s", k, props[k])
+	}
+	return out
+}
+
+// WriteDOT writes the graph as a GraphViz DOT digraph for debugging, with
+// nodes shaped by type and edges labeled by relationship type.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph orggraph {"); err != nil {
+		return err
+	}
+	for _, n := range g.Nodes {
+		label := n.ID
+		if name, ok := n.Properties["name"]; ok {
+			label = name
+		}
+		if _, err := fmt.Fprintf(w, "  %q [label=%q, shape=%s];\n", n.ID, label, dotShape(n.Type)); err != nil {
+			return err
+		}
+	}
+	for _, e := range g.Edges {
+		if _, err := fmt.Fprintf(w, "  %q -> %q [label=%q];\n", e.From, e.To, e.Type); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w, "}"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func dotShape(t NodeType) string {
+	switch t {
+	case NodeOrganization:
+		return "doublecircle"
+	case NodeOU:
+		return "folder"
+	case NodeAccount:
+		return "box"
+	case NodeTag:
+		return "ellipse"
+	default:
+		return "plaintext"
+	}
+}
@@ -0,0 +1,80 @@
+package orggraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func sampleGraph() *Graph {
+	g := NewGraph()
+	g.AddNode(Node{ID: OrgNodeID(), Type: NodeOrganization})
+	g.AddNode(Node{ID: OUNodeID("ou-1"), Type: NodeOU, Properties: map[string]string{"id": "ou-1"}})
+	g.AddNode(Node{ID: AccountNodeID("111"), Type: NodeAccount, Properties: map[string]string{"name": "prod-a"}})
+	g.AddNode(Node{ID: TagNodeID("Environment", "prod"), Type: NodeTag, Properties: map[string]string{"key": "Environment", "value": "prod"}})
+	g.AddEdge(Edge{From: OrgNodeID(), To: OUNodeID("ou-1"), Type: EdgeContains})
+	g.AddEdge(Edge{From: OUNodeID("ou-1"), To: AccountNodeID("111"), Type: EdgeContains})
+	g.AddEdge(Edge{From: AccountNodeID("111"), To: TagNodeID("Environment", "prod"), Type: EdgeTagged})
+	return g
+}
+
+func TestToJSONRoundTrips(t *testing.T) {
+	g := sampleGraph()
+	data, err := g.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+
+	var decoded Graph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(decoded.Nodes) != len(g.Nodes) || len(decoded.Edges) != len(g.Edges) {
+		t.Fatalf("decoded graph = %d nodes / %d edges, want %d / %d",
+			len(decoded.Nodes), len(decoded.Edges), len(g.Nodes), len(g.Edges))
+	}
+}
+
+func TestWriteNeo4jCSVHeadersAndRowCounts(t *testing.T) {
+	g := sampleGraph()
+	var nodesBuf, edgesBuf bytes.Buffer
+	if err := g.WriteNeo4jCSV(&nodesBuf, &edgesBuf); err != nil {
+		t.Fatalf("WriteNeo4jCSV: %v", err)
+	}
+
+	nodeLines := strings.Split(strings.TrimSpace(nodesBuf.String()), "\n")
+	if nodeLines[0] != "id:ID,label,properties" {
+		t.Fatalf("nodes.csv header = %q, want id:ID,label,properties", nodeLines[0])
+	}
+	if len(nodeLines)-1 != len(g.Nodes) {
+		t.Fatalf("nodes.csv has %d data rows, want %d", len(nodeLines)-1, len(g.Nodes))
+	}
+
+	edgeLines := strings.Split(strings.TrimSpace(edgesBuf.String()), "\n")
+	if edgeLines[0] != ":START_ID,:END_ID,:TYPE" {
+		t.Fatalf("edges.csv header = %q, want :START_ID,:END_ID,:TYPE", edgeLines[0])
+	}
+	if len(edgeLines)-1 != len(g.Edges) {
+		t.Fatalf("edges.csv has %d data rows, want %d", len(edgeLines)-1, len(g.Edges))
+	}
+}
+
+func TestWriteDOTProducesValidDigraphShape(t *testing.T) {
+	g := sampleGraph()
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "digraph orggraph {") {
+		t.Fatalf("DOT output must open with 'digraph orggraph {', got: %q", out[:min(40, len(out))])
+	}
+	if !strings.HasSuffix(strings.TrimSpace(out), "}") {
+		t.Fatalf("DOT output must close with '}'")
+	}
+	if strings.Count(out, "->") != len(g.Edges) {
+		t.Fatalf("DOT output has %d edges, want %d", strings.Count(out, "->"), len(g.Edges))
+	}
+}
@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// resolveOUPath translates a human-readable OU path such as
+// "/Root/Production/DataPlatform" into a concrete OU ID by walking from the
+// organization root and matching each path segment against the child OUs'
+// names. This lets config files reference OUs by path instead of by ID, so
+// the same config works across accounts where an OU has a different ID.
+//
+// Resolutions are cached on d for the lifetime of the DiscoveryService so
+// that repeated pipeline stages referencing the same path don't re-walk the
+// tree.
+func (d *DiscoveryService) resolveOUPath(path string) (string, error) {
+	if cached, ok := d.lookupOUPathCache(path); ok {
+		return cached, nil
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return "", fmt.Errorf("empty OU path")
+	}
+
+	currentID, err := d.awsCtx.GetOrganizationRoot(d.ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get organization root: %w", err)
+	}
+
+	// segments[0] names the root itself (conventionally "Root"); resolution
+	// proceeds from the remaining segments.
+	for _, segment := range segments[1:] {
+		childOUs, err := d.awsCtx.ListChildOUs(d.ctx, currentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to list child OUs of %s: %w", currentID, err)
+		}
+
+		matchID := ""
+		for _, childID := range childOUs {
+			name, err := d.awsCtx.DescribeOU(d.ctx, childID)
+			if err != nil {
+				return "", fmt.Errorf("failed to describe OU %s: %w", childID, err)
+			}
+			if name == segment {
+				matchID = childID
+				break
+			}
+		}
+		if matchID == "" {
+			return "", fmt.Errorf("OU path segment %q not found under %s", segment, currentID)
+		}
+		currentID = matchID
+	}
+
+	d.storeOUPathCache(path, currentID)
+	return currentID, nil
+}
+
+func (d *DiscoveryService) lookupOUPathCache(path string) (string, bool) {
+	d.ouPathCacheMu.Lock()
+	defer d.ouPathCacheMu.Unlock()
+	if d.ouPathCache == nil {
+		return "", false
+	}
+	id, ok := d.ouPathCache[path]
+	return id, ok
+}
+
+func (d *DiscoveryService) storeOUPathCache(path, id string) {
+	d.ouPathCacheMu.Lock()
+	defer d.ouPathCacheMu.Unlock()
+	if d.ouPathCache == nil {
+		d.ouPathCache = make(map[string]string)
+	}
+	d.ouPathCache[path] = id
+}
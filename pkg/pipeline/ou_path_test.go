@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+)
+
+// countingOrgContext wraps a fakeOrgContext and counts calls to
+// ListChildOUs/DescribeOU, so tests can assert the path cache actually
+// avoids re-walking the tree.
+type countingOrgContext struct {
+	*fakeOrgContext
+	listChildOUsCalls int
+	describeOUCalls   int
+}
+
+func (c *countingOrgContext) ListChildOUs(ctx context.Context, ouID string) ([]string, error) {
+	c.listChildOUsCalls++
+	return c.fakeOrgContext.ListChildOUs(ctx, ouID)
+}
+
+func (c *countingOrgContext) DescribeOU(ctx context.Context, ouID string) (string, error) {
+	c.describeOUCalls++
+	return c.fakeOrgContext.DescribeOU(ctx, ouID)
+}
+
+func TestResolveOUPathWalksFromRoot(t *testing.T) {
+	tree := &countingOrgContext{fakeOrgContext: newTestOrgTree()}
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: tree}
+
+	id, err := d.resolveOUPath("/root/a/a1")
+	if err != nil {
+		t.Fatalf("resolveOUPath: %v", err)
+	}
+	if id != "a1" {
+		t.Fatalf("resolveOUPath(/root/a/a1) = %q, want %q", id, "a1")
+	}
+}
+
+func TestResolveOUPathUnknownSegmentErrors(t *testing.T) {
+	tree := &countingOrgContext{fakeOrgContext: newTestOrgTree()}
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: tree}
+
+	if _, err := d.resolveOUPath("/root/does-not-exist"); err == nil {
+		t.Fatalf("expected an error resolving a path with an unknown segment")
+	}
+}
+
+func TestResolveOUPathCachesRepeatedLookups(t *testing.T) {
+	tree := &countingOrgContext{fakeOrgContext: newTestOrgTree()}
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: tree}
+
+	if _, err := d.resolveOUPath("/root/a/a1"); err != nil {
+		t.Fatalf("first resolveOUPath: %v", err)
+	}
+	firstListChildOUsCalls := tree.listChildOUsCalls
+	firstDescribeOUCalls := tree.describeOUCalls
+	if firstListChildOUsCalls == 0 {
+		t.Fatalf("expected the first resolution to actually walk the tree")
+	}
+
+	if _, err := d.resolveOUPath("/root/a/a1"); err != nil {
+		t.Fatalf("second resolveOUPath: %v", err)
+	}
+
+	if tree.listChildOUsCalls != firstListChildOUsCalls || tree.describeOUCalls != firstDescribeOUCalls {
+		t.Fatalf("second resolveOUPath re-walked the tree: ListChildOUs %d->%d, DescribeOU %d->%d",
+			firstListChildOUsCalls, tree.listChildOUsCalls, firstDescribeOUCalls, tree.describeOUCalls)
+	}
+}
@@ -0,0 +1,268 @@
+package pipeline
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"github.com/extended-data-library/secretssync/pkg/pipeline/orggraph"
+)
+
+// defaultOUWorkers is used when OrganizationsDiscovery.Workers is unset.
+const defaultOUWorkers = 8
+
+// organizationsAPIRateLimit is a conservative default QPS for the
+// Organizations ListAccountsInOU/ListChildOUs calls, which are known to be
+// far more throttle-happy than most other AWS APIs.
+const organizationsAPIRateLimit = 5
+const organizationsAPIBurst = 5
+
+// ouJob is a single unit of work for the worker pool: list the accounts and
+// child OUs of one OU at a known depth. parentNodeID is only meaningful
+// when the walker is recording a graph (see ouWalker.graph) and names the
+// graph node a CONTAINS edge should be drawn from.
+type ouJob struct {
+	ouID         string
+	depth        int
+	parentNodeID string
+}
+
+// ouWalker drives a bounded-concurrency, cancellation-aware traversal of an
+// Organizations OU tree. Pending work is a channel of ouJob; each of the N
+// workers lists accounts and child OUs for a job and, for every
+// non-excluded child, enqueues a new job and bumps the WaitGroup before
+// doing so; a job for an already-visited OU is pruned when it is processed. The channel is closed once the WaitGroup reaches zero, which
+// terminates the workers.
+type ouWalker struct {
+	d        *DiscoveryService
+	maxDepth int
+	exclude  map[string]struct{}
+	limiter  *rate.Limiter
+	workers  int
+
+	jobs chan ouJob
+	wg   sync.WaitGroup
+
+	// graph, when non-nil, switches the walker into structure-recording
+	// mode: instead of (only) flattening accounts, process() also records
+	// Organization/OU/Account/Tag nodes and CONTAINS/TAGGED edges into it.
+	// Writes are guarded by mu along with the other shared state below.
+	graph *orggraph.Graph
+
+	mu       sync.Mutex
+	visited  map[string]struct{}
+	accounts []AccountInfo
+	stats    *ouTraversalStats
+	errs     *multierror.Error
+}
+
+// newOUWalker builds an ouWalker for a single discoverFromOrganizations
+// call, sized and configured from cfg.
+func (d *DiscoveryService) newOUWalker(cfg *OrganizationsDiscovery) *ouWalker {
+	exclude := make(map[string]struct{}, len(cfg.ExcludeOUs))
+	for _, id := range cfg.ExcludeOUs {
+		exclude[id] = struct{}{}
+	}
+
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = defaultOUWorkers
+	}
+
+	return &ouWalker{
+		d:        d,
+		maxDepth: cfg.MaxDepth,
+		exclude:  exclude,
+		limiter:  rate.NewLimiter(rate.Limit(organizationsAPIRateLimit), organizationsAPIBurst),
+		workers:  workers,
+		jobs:     make(chan ouJob, workers*4),
+		visited:  make(map[string]struct{}),
+		stats:    &ouTraversalStats{},
+	}
+}
+
+// newOUGraphWalker builds an ouWalker exactly like newOUWalker, but in
+// structure-recording mode: process() will populate g with
+// Organization/OU/Account/Tag nodes and CONTAINS/TAGGED edges instead of
+// only flattening accounts. This lets DiscoverOrgGraph reuse the same
+// bounded worker pool, rate limiting and cancellation handling as the plain
+// account listing path.
+func (d *DiscoveryService) newOUGraphWalker(cfg *OrganizationsDiscovery, g *orggraph.Graph) *ouWalker {
+	w := d.newOUWalker(cfg)
+	w.graph = g
+	return w
+}
+
+// run starts the worker pool, seeds it with rootOU (rootParentNodeID is the
+// graph node a CONTAINS edge from rootOU should originate from, and is
+// ignored unless the walker is recording a graph), waits for the traversal
+// to drain, and returns the merged accounts and stats. Per-OU errors are
+// coalesced into a single multierror rather than aborting the whole walk;
+// run itself returns that error only when at least one OU failed and no
+// accounts were collected at all.
+func (w *ouWalker) run(rootOU, rootParentNodeID string) ([]AccountInfo, *ouTraversalStats, error) {
+	var workerWG sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			w.drain()
+		}()
+	}
+
+	// The root OU is depth 0 so that MaxDepth=1 ("direct children only")
+	// reaches the OUs one level below root, not the root itself.
+	w.enqueue(ouJob{ouID: rootOU, depth: 0, parentNodeID: rootParentNodeID})
+
+	go func() {
+		w.wg.Wait()
+		close(w.jobs)
+	}()
+	workerWG.Wait()
+
+	if err := w.errs.ErrorOrNil(); err != nil && len(w.accounts) == 0 {
+		return nil, nil, err
+	}
+	return w.accounts, w.stats, nil
+}
+
+// enqueue registers pending work for ouJob and hands it to a worker. It is
+// safe to call from any worker goroutine.
+func (w *ouWalker) enqueue(job ouJob) {
+	w.wg.Add(1)
+	go func() {
+		w.jobs <- job
+	}()
+}
+
+// drain is the worker loop: process jobs until the channel is closed. Once
+// the pipeline's context is cancelled, process drops each remaining job
+// without enqueueing children, so the channel drains and is closed.
+func (w *ouWalker) drain() {
+	for job := range w.jobs {
+		w.process(job)
+	}
+}
+
+// process handles a single OU: skip it if already visited or excluded, list
+// its accounts, record stats, and enqueue its non-excluded children (unless
+// maxDepth has been reached).
+func (w *ouWalker) process(job ouJob) {
+	defer w.wg.Done()
+
+	if err := w.d.ctx.Err(); err != nil {
+		w.addError(job.ouID, fmt.Errorf("traversal cancelled: %w", err))
+		return
+	}
+
+	if !w.markVisited(job.ouID) {
+		w.recordPruned()
+		return
+	}
+
+	w.recordDepth(job.depth)
+
+	if err := w.limiter.Wait(w.d.ctx); err != nil {
+		w.addError(job.ouID, fmt.Errorf("rate limiter: %w", err))
+		return
+	}
+	ouAccounts, err := w.d.awsCtx.ListAccountsInOU(w.d.ctx, job.ouID)
+	if err != nil {
+		w.addError(job.ouID, fmt.Errorf("failed to list accounts in OU %s: %w", job.ouID, err))
+		return
+	}
+	w.addAccounts(ouAccounts)
+
+	ouNodeID := orggraph.OUNodeID(job.ouID)
+	if w.graph != nil {
+		w.recordOUGraph(job.parentNodeID, ouNodeID, job.ouID, ouAccounts)
+	}
+
+	if w.maxDepth != 0 && job.depth >= w.maxDepth {
+		return
+	}
+
+	if err := w.limiter.Wait(w.d.ctx); err != nil {
+		w.addError(job.ouID, fmt.Errorf("rate limiter: %w", err))
+		return
+	}
+	childOUs, err := w.d.awsCtx.ListChildOUs(w.d.ctx, job.ouID)
+	if err != nil {
+		// Log but continue - we might not have permission to list child OUs
+		log.WithError(err).WithField("ou", job.ouID).Debug("Could not list child OUs")
+		return
+	}
+
+	for _, childOU := range childOUs {
+		if _, excluded := w.exclude[childOU]; excluded {
+			w.recordPruned()
+			continue
+		}
+		w.enqueue(ouJob{ouID: childOU, depth: job.depth + 1, parentNodeID: ouNodeID})
+	}
+}
+
+// recordOUGraph adds the OU node and its CONTAINS/TAGGED structure
+// (parent->OU, OU->account, account->tag) to w.graph.
+func (w *ouWalker) recordOUGraph(parentNodeID, ouNodeID, ouID string, accounts []AccountInfo) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.graph.AddNode(orggraph.Node{ID: ouNodeID, Type: orggraph.NodeOU, Properties: map[string]string{"id": ouID}})
+	w.graph.AddEdge(orggraph.Edge{From: parentNodeID, To: ouNodeID, Type: orggraph.EdgeContains})
+
+	for _, acct := range accounts {
+		acctNodeID := orggraph.AccountNodeID(acct.ID)
+		w.graph.AddNode(orggraph.Node{ID: acctNodeID, Type: orggraph.NodeAccount, Properties: map[string]string{"id": acct.ID, "name": acct.Name, "status": acct.Status}})
+		w.graph.AddEdge(orggraph.Edge{From: ouNodeID, To: acctNodeID, Type: orggraph.EdgeContains})
+
+		for key, value := range acct.Tags {
+			tagNodeID := orggraph.TagNodeID(key, value)
+			w.graph.AddNode(orggraph.Node{ID: tagNodeID, Type: orggraph.NodeTag, Properties: map[string]string{"key": key, "value": value}})
+			w.graph.AddEdge(orggraph.Edge{From: acctNodeID, To: tagNodeID, Type: orggraph.EdgeTagged})
+		}
+	}
+}
+
+func (w *ouWalker) markVisited(ouID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, excluded := w.exclude[ouID]; excluded {
+		return false
+	}
+	if _, seen := w.visited[ouID]; seen {
+		return false
+	}
+	w.visited[ouID] = struct{}{}
+	return true
+}
+
+func (w *ouWalker) recordPruned() {
+	w.mu.Lock()
+	w.stats.prunedOUs++
+	w.mu.Unlock()
+}
+
+func (w *ouWalker) recordDepth(depth int) {
+	w.mu.Lock()
+	if depth > w.stats.depthReached {
+		w.stats.depthReached = depth
+	}
+	w.mu.Unlock()
+}
+
+func (w *ouWalker) addAccounts(accounts []AccountInfo) {
+	w.mu.Lock()
+	w.accounts = append(w.accounts, accounts...)
+	w.mu.Unlock()
+}
+
+func (w *ouWalker) addError(ouID string, err error) {
+	log.WithError(err).WithField("ou", ouID).Debug("Error during OU traversal")
+	w.mu.Lock()
+	w.errs = multierror.Append(w.errs, fmt.Errorf("ou %s: %w", ouID, err))
+	w.mu.Unlock()
+}
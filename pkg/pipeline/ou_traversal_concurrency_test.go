@@ -0,0 +1,113 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// failingOrgContext wraps a fakeOrgContext so that ListAccountsInOU fails
+// for a configured set of OU IDs, letting tests exercise the worker pool's
+// per-branch error coalescing.
+type failingOrgContext struct {
+	*fakeOrgContext
+	failOn map[string]struct{}
+}
+
+func (f *failingOrgContext) ListAccountsInOU(ctx context.Context, ouID string) ([]AccountInfo, error) {
+	if _, fail := f.failOn[ouID]; fail {
+		return nil, fmt.Errorf("simulated failure for OU %s", ouID)
+	}
+	return f.fakeOrgContext.ListAccountsInOU(ctx, ouID)
+}
+
+// wideOrgTree builds a root with many direct children, each holding one
+// account, to exercise the worker pool fan-out with more jobs than
+// workers.
+func wideOrgTree(n int) *fakeOrgContext {
+	tree := &fakeOrgContext{
+		root:     "root",
+		children: map[string][]string{},
+		accounts: map[string][]AccountInfo{},
+		names:    map[string]string{},
+	}
+	var children []string
+	for i := 0; i < n; i++ {
+		ou := fmt.Sprintf("ou-%d", i)
+		children = append(children, ou)
+		tree.accounts[ou] = []AccountInfo{{ID: fmt.Sprintf("acct-%d", i)}}
+	}
+	tree.children["root"] = children
+	return tree
+}
+
+func TestOUWalkerConcurrentFanOutVisitsEveryOUExactlyOnce(t *testing.T) {
+	const n = 12
+	tree := wideOrgTree(n)
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: tree}
+	cfg := &OrganizationsDiscovery{Workers: 4}
+
+	accounts, _, err := d.listAccountsInOURecursive("root", cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	seen := make(map[string]int)
+	for _, a := range accounts {
+		seen[a.ID]++
+	}
+	if len(seen) != n {
+		t.Fatalf("got %d distinct accounts, want %d", len(seen), n)
+	}
+	for id, count := range seen {
+		if count != 1 {
+			t.Fatalf("account %s seen %d times, want exactly once", id, count)
+		}
+	}
+}
+
+func TestOUWalkerCoalescesPerBranchErrors(t *testing.T) {
+	tree := &failingOrgContext{
+		fakeOrgContext: newTestOrgTree(),
+		failOn:         map[string]struct{}{"a": {}},
+	}
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: tree}
+	cfg := &OrganizationsDiscovery{}
+
+	accounts, _, err := d.listAccountsInOURecursive("root", cfg)
+	if err != nil {
+		t.Fatalf("a single failing branch must not abort the whole traversal, got err: %v", err)
+	}
+
+	ids := accountIDs(accounts)
+	for _, want := range []string{"root-acct", "b-acct"} {
+		found := false
+		for _, id := range ids {
+			if id == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("accounts = %v, want to include %s from the surviving branch", ids, want)
+		}
+	}
+	for _, id := range ids {
+		if id == "a-acct" || id == "a1-acct" || id == "a2-acct" {
+			t.Fatalf("accounts = %v, must not include accounts under the failed OU a", ids)
+		}
+	}
+}
+
+func TestOUWalkerReturnsErrorWhenEveryBranchFails(t *testing.T) {
+	tree := &failingOrgContext{
+		fakeOrgContext: newTestOrgTree(),
+		failOn:         map[string]struct{}{"root": {}},
+	}
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: tree}
+	cfg := &OrganizationsDiscovery{}
+
+	_, _, err := d.listAccountsInOURecursive("root", cfg)
+	if err == nil {
+		t.Fatalf("expected an error when the only branch (root itself) fails")
+	}
+}
@@ -0,0 +1,154 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+// fakeOrgContext is a minimal in-memory organizationsAWSContext used to
+// exercise ouWalker without a real AWS client.
+type fakeOrgContext struct {
+	children map[string][]string
+	accounts map[string][]AccountInfo
+	names    map[string]string
+	root     string
+}
+
+func (f *fakeOrgContext) CanAccessOrganizations() bool { return true }
+
+func (f *fakeOrgContext) ListAccountsInOU(_ context.Context, ouID string) ([]AccountInfo, error) {
+	return f.accounts[ouID], nil
+}
+
+func (f *fakeOrgContext) ListChildOUs(_ context.Context, ouID string) ([]string, error) {
+	return f.children[ouID], nil
+}
+
+func (f *fakeOrgContext) ListOrganizationAccounts(_ context.Context) ([]AccountInfo, error) {
+	var all []AccountInfo
+	for _, accts := range f.accounts {
+		all = append(all, accts...)
+	}
+	return all, nil
+}
+
+func (f *fakeOrgContext) GetOrganizationRoot(_ context.Context) (string, error) {
+	return f.root, nil
+}
+
+func (f *fakeOrgContext) DescribeOU(_ context.Context, ouID string) (string, error) {
+	return f.names[ouID], nil
+}
+
+// newTestOrgTree builds:
+//
+//	root -> a -> a1
+//	     -> a -> a2 -> root (cycle back to root)
+//	     -> b
+func newTestOrgTree() *fakeOrgContext {
+	return &fakeOrgContext{
+		root: "root",
+		children: map[string][]string{
+			"root": {"a", "b"},
+			"a":    {"a1", "a2"},
+			"a2":   {"root"},
+		},
+		accounts: map[string][]AccountInfo{
+			"root": {{ID: "root-acct"}},
+			"a":    {{ID: "a-acct"}},
+			"a1":   {{ID: "a1-acct"}},
+			"a2":   {{ID: "a2-acct"}},
+			"b":    {{ID: "b-acct"}},
+		},
+		names: map[string]string{
+			"a": "a", "a1": "a1", "a2": "a2", "b": "b",
+		},
+	}
+}
+
+func accountIDs(accounts []AccountInfo) []string {
+	ids := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		ids = append(ids, a.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestOUWalkerUnlimitedDepthVisitsEachOUOnce(t *testing.T) {
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: newTestOrgTree()}
+	cfg := &OrganizationsDiscovery{}
+
+	accounts, stats, err := d.listAccountsInOURecursive("root", cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := accountIDs(accounts)
+	want := []string{"a-acct", "a1-acct", "a2-acct", "b-acct", "root-acct"}
+	if len(got) != len(want) {
+		t.Fatalf("accounts = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("accounts = %v, want %v", got, want)
+		}
+	}
+
+	// The a2 -> root edge is a cycle; root must be pruned as already
+	// visited rather than re-walked.
+	if stats.prunedOUs < 1 {
+		t.Fatalf("prunedOUs = %d, want at least 1 for the root cycle", stats.prunedOUs)
+	}
+}
+
+func TestOUWalkerMaxDepthOneReturnsRootAndDirectChildrenOnly(t *testing.T) {
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: newTestOrgTree()}
+	cfg := &OrganizationsDiscovery{MaxDepth: 1}
+
+	accounts, stats, err := d.listAccountsInOURecursive("root", cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := accountIDs(accounts)
+	want := []string{"a-acct", "b-acct", "root-acct"}
+	if len(got) != len(want) {
+		t.Fatalf("accounts = %v, want %v (grandchildren a1/a2 must not be reached)", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("accounts = %v, want %v", got, want)
+		}
+	}
+
+	if stats.depthReached != 1 {
+		t.Fatalf("depthReached = %d, want 1", stats.depthReached)
+	}
+}
+
+func TestOUWalkerExcludeOUsPrunesSubtree(t *testing.T) {
+	d := &DiscoveryService{ctx: context.Background(), awsCtx: newTestOrgTree()}
+	cfg := &OrganizationsDiscovery{ExcludeOUs: []string{"a"}}
+
+	accounts, stats, err := d.listAccountsInOURecursive("root", cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := accountIDs(accounts)
+	want := []string{"b-acct", "root-acct"}
+	if len(got) != len(want) {
+		t.Fatalf("accounts = %v, want %v (a and its descendants must be pruned)", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("accounts = %v, want %v", got, want)
+		}
+	}
+
+	if stats.prunedOUs < 1 {
+		t.Fatalf("prunedOUs = %d, want at least 1 for excluded OU a", stats.prunedOUs)
+	}
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import "testing"
+
+func TestFilterAccountsByStatusDefaultsToActiveOnly(t *testing.T) {
+	accounts := []AccountInfo{
+		{ID: "1", Status: "ACTIVE"},
+		{ID: "2", Status: "SUSPENDED"},
+		{ID: "3", Status: "PENDING_CLOSURE"},
+	}
+
+	got := filterAccountsByStatus(accounts, nil, nil)
+
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Fatalf("filterAccountsByStatus(nil, nil) = %+v, want only the ACTIVE account", got)
+	}
+}
+
+func TestFilterAccountsByStatusExplicitInclude(t *testing.T) {
+	accounts := []AccountInfo{
+		{ID: "1", Status: "ACTIVE"},
+		{ID: "2", Status: "SUSPENDED"},
+	}
+
+	got := filterAccountsByStatus(accounts, []string{"ACTIVE", "SUSPENDED"}, nil)
+
+	if len(got) != 2 {
+		t.Fatalf("filterAccountsByStatus with explicit IncludeStatuses = %+v, want both accounts", got)
+	}
+}
+
+func TestFilterAccountsByStatusExcludeCarvesOutOfInclude(t *testing.T) {
+	accounts := []AccountInfo{
+		{ID: "1", Status: "ACTIVE"},
+		{ID: "2", Status: "SUSPENDED"},
+	}
+
+	got := filterAccountsByStatus(accounts, []string{"ACTIVE", "SUSPENDED"}, []string{"SUSPENDED"})
+
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Fatalf("filterAccountsByStatus with ExcludeStatuses = %+v, want only the ACTIVE account", got)
+	}
+}
+
+func TestFilterAccountsByStatusZeroValueStatusIsExcludedByDefault(t *testing.T) {
+	// An AccountInfo whose Status was never populated must not silently
+	// pass the default ACTIVE-only filter.
+	accounts := []AccountInfo{{ID: "1"}}
+
+	got := filterAccountsByStatus(accounts, nil, nil)
+
+	if len(got) != 0 {
+		t.Fatalf("filterAccountsByStatus(nil, nil) with unset Status = %+v, want none", got)
+	}
+}
@@ -0,0 +1,375 @@
+package pipeline
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TagExpr is a parsed boolean tag-filter expression, e.g.
+//
+//	Environment=prod AND (Owner=team-a OR Owner=team-b) AND CostCenter!=X-*
+//
+// Use ParseTagExpression to build one from a string and Eval to test it
+// against the tags of a single account.
+type TagExpr interface {
+	Eval(tags map[string]string) bool
+}
+
+// andExpr is satisfied when both operands are satisfied.
+type andExpr struct {
+	left, right TagExpr
+}
+
+func (e *andExpr) Eval(tags map[string]string) bool {
+	return e.left.Eval(tags) && e.right.Eval(tags)
+}
+
+// orExpr is satisfied when either operand is satisfied.
+type orExpr struct {
+	left, right TagExpr
+}
+
+func (e *orExpr) Eval(tags map[string]string) bool {
+	return e.left.Eval(tags) || e.right.Eval(tags)
+}
+
+// notExpr negates its operand.
+type notExpr struct {
+	inner TagExpr
+}
+
+func (e *notExpr) Eval(tags map[string]string) bool {
+	return !e.inner.Eval(tags)
+}
+
+// cmpOp is a comparison operator recognized on a tag atom.
+type cmpOp string
+
+const (
+	cmpEq  cmpOp = "="
+	cmpNeq cmpOp = "!="
+	cmpLt  cmpOp = "<"
+	cmpLte cmpOp = "<="
+	cmpGt  cmpOp = ">"
+	cmpGte cmpOp = ">="
+	cmpIn  cmpOp = "in"
+)
+
+// cmpExpr compares a single tag's value against a literal (or, for cmpIn, a
+// list of literals). Missing tags are treated as "" for cmpEq/cmpNeq and as
+// unsatisfied for every other operator.
+type cmpExpr struct {
+	key    string
+	op     cmpOp
+	value  string
+	values []string
+}
+
+func (e *cmpExpr) Eval(tags map[string]string) bool {
+	actual, present := tags[e.key]
+
+	switch e.op {
+	case cmpEq:
+		return globOrExactMatch(actual, e.value)
+	case cmpNeq:
+		return !globOrExactMatch(actual, e.value)
+	case cmpIn:
+		if !present {
+			return false
+		}
+		for _, v := range e.values {
+			if globOrExactMatch(actual, v) {
+				return true
+			}
+		}
+		return false
+	case cmpLt, cmpLte, cmpGt, cmpGte:
+		if !present {
+			return false
+		}
+		return numericOrLexicalCompare(actual, e.value, e.op)
+	default:
+		return false
+	}
+}
+
+// globOrExactMatch compares actual against pattern, treating a "*" in
+// pattern as a glob wildcard and falling back to an exact match otherwise.
+func globOrExactMatch(actual, pattern string) bool {
+	if strings.Contains(pattern, "*") {
+		matched, err := filepath.Match(pattern, actual)
+		return err == nil && matched
+	}
+	return actual == pattern
+}
+
+// numericOrLexicalCompare compares actual against value using op, comparing
+// numerically when both sides look like numbers and falling back to a
+// lexical comparison otherwise.
+func numericOrLexicalCompare(actual, value string, op cmpOp) bool {
+	af, aerr := strconv.ParseFloat(actual, 64)
+	vf, verr := strconv.ParseFloat(value, 64)
+
+	if aerr == nil && verr == nil {
+		switch op {
+		case cmpLt:
+			return af < vf
+		case cmpLte:
+			return af <= vf
+		case cmpGt:
+			return af > vf
+		case cmpGte:
+			return af >= vf
+		}
+	}
+
+	switch op {
+	case cmpLt:
+		return actual < value
+	case cmpLte:
+		return actual <= value
+	case cmpGt:
+		return actual > value
+	case cmpGte:
+		return actual >= value
+	}
+	return false
+}
+
+// tagExprParser parses a tokenized boolean tag expression into a TagExpr
+// AST using a standard recursive-descent grammar:
+//
+//	expr   := term (OR term)*
+//	term   := factor (AND factor)*
+//	factor := NOT factor | "(" expr ")" | atom
+//	atom   := KEY op value
+type tagExprParser struct {
+	tokens []string
+	pos    int
+}
+
+// ParseTagExpression parses a boolean tag-filter expression string into a
+// TagExpr. Supported atoms are `key=value`, `key!=value`, `key<value`,
+// `key<=value`, `key>value`, `key>=value`, `key in [a,b,c]`, combined with
+// AND, OR, NOT and parentheses. Values may contain a `*` glob wildcard for
+// `=`, `!=` and `in`. Returns an error on malformed input so callers can
+// fail fast rather than silently discover accounts with an empty filter.
+func ParseTagExpression(expr string) (TagExpr, error) {
+	tokens, err := tokenizeTagExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("tag expression: empty expression")
+	}
+
+	p := &tagExprParser{tokens: tokens}
+	result, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("tag expression: unexpected token %q", p.tokens[p.pos])
+	}
+	return result, nil
+}
+
+func (p *tagExprParser) parseExpr() (TagExpr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *tagExprParser) parseTerm() (TagExpr, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "AND" {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *tagExprParser) parseFactor() (TagExpr, error) {
+	switch p.peek() {
+	case "NOT":
+		p.pos++
+		inner, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{inner: inner}, nil
+	case "(":
+		p.pos++
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("tag expression: expected ')', got %q", p.peek())
+		}
+		p.pos++
+		return inner, nil
+	default:
+		return p.parseAtom()
+	}
+}
+
+func (p *tagExprParser) parseAtom() (TagExpr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("tag expression: unexpected end of input")
+	}
+	key := p.tokens[p.pos]
+	if key == "" || key == "AND" || key == "OR" || key == "NOT" || key == ")" || key == "(" {
+		return nil, fmt.Errorf("tag expression: expected tag key, got %q", key)
+	}
+	p.pos++
+
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("tag expression: expected operator after %q", key)
+	}
+	op := p.tokens[p.pos]
+	p.pos++
+
+	switch cmpOp(op) {
+	case cmpEq, cmpNeq, cmpLt, cmpLte, cmpGt, cmpGte:
+		if p.pos >= len(p.tokens) {
+			return nil, fmt.Errorf("tag expression: expected value after %q %q", key, op)
+		}
+		value := p.tokens[p.pos]
+		p.pos++
+		return &cmpExpr{key: key, op: cmpOp(op), value: value}, nil
+	case cmpIn:
+		if p.peek() != "[" {
+			return nil, fmt.Errorf("tag expression: expected '[' after 'in', got %q", p.peek())
+		}
+		p.pos++
+		var values []string
+		for p.peek() != "]" {
+			if p.pos >= len(p.tokens) {
+				return nil, fmt.Errorf("tag expression: unterminated 'in [...]' list")
+			}
+			values = append(values, p.tokens[p.pos])
+			p.pos++
+			if p.peek() == "," {
+				p.pos++
+			}
+		}
+		p.pos++ // consume "]"
+		return &cmpExpr{key: key, op: cmpIn, values: values}, nil
+	default:
+		return nil, fmt.Errorf("tag expression: unknown operator %q after %q", op, key)
+	}
+}
+
+func (p *tagExprParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+// filterAccountsByTagExpression keeps only the accounts whose tags satisfy
+// expr, evaluating it once per account.
+func filterAccountsByTagExpression(accounts []AccountInfo, expr TagExpr) []AccountInfo {
+	var filtered []AccountInfo
+	for _, acct := range accounts {
+		if expr.Eval(acct.Tags) {
+			filtered = append(filtered, acct)
+		}
+	}
+	return filtered
+}
+
+// tokenizeTagExpression splits a tag expression into tokens: the keywords
+// AND/OR/NOT, parentheses/brackets/commas, operators, and bare words (tag
+// keys and values). Quoted values ("a b", 'a b') are kept as single tokens.
+func tokenizeTagExpression(expr string) ([]string, error) {
+	var tokens []string
+	i := 0
+	runes := []rune(expr)
+
+	for i < len(runes) {
+		c := runes[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']' || c == ',':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"' || c == '\'':
+			quote := c
+			j := i + 1
+			for j < len(runes) && runes[j] != quote {
+				j++
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("tag expression: unterminated quoted value")
+			}
+			tokens = append(tokens, string(runes[i+1:j]))
+			i = j + 1
+		case c == '!' || c == '<' || c == '>' || c == '=':
+			j := i + 1
+			if j < len(runes) && runes[j] == '=' {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		default:
+			j := i
+			for j < len(runes) && !strings.ContainsRune(" \t\n()[],", runes[j]) && runes[j] != '=' && runes[j] != '!' && runes[j] != '<' && runes[j] != '>' {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("tag expression: unexpected character %q", string(c))
+			}
+			word := string(runes[i:j])
+			switch {
+			case isBooleanKeyword(word) && !followedByOperator(runes, j):
+				tokens = append(tokens, strings.ToUpper(word))
+			case strings.EqualFold(word, "in"):
+				tokens = append(tokens, string(cmpIn))
+			default:
+				tokens = append(tokens, word)
+			}
+			i = j
+		}
+	}
+
+	return tokens, nil
+}
+
+// isBooleanKeyword reports whether word is AND/OR/NOT, case-insensitively.
+func isBooleanKeyword(word string) bool {
+	return strings.EqualFold(word, "AND") || strings.EqualFold(word, "OR") || strings.EqualFold(word, "NOT")
+}
+
+// followedByOperator reports whether, skipping whitespace, the next rune
+// starting at pos begins a comparison operator (=, !=, <, <=, >, >=). A bare
+// word that looks like AND/OR/NOT but is immediately followed by an
+// operator is a tag key (e.g. "and=prod"), not the boolean keyword - AWS
+// tag keys aren't restricted from colliding with this grammar's keywords.
+func followedByOperator(runes []rune, pos int) bool {
+	for pos < len(runes) && (runes[pos] == ' ' || runes[pos] == '\t' || runes[pos] == '\n') {
+		pos++
+	}
+	return pos < len(runes) && strings.ContainsRune("=!<>", runes[pos])
+}
@@ -0,0 +1,143 @@
+package pipeline
+
+import "testing"
+
+func TestParseTagExpressionEval(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		tags map[string]string
+		want bool
+	}{
+		{
+			name: "simple equality",
+			expr: "Environment=prod",
+			tags: map[string]string{"Environment": "prod"},
+			want: true,
+		},
+		{
+			name: "not equal",
+			expr: "Environment!=prod",
+			tags: map[string]string{"Environment": "staging"},
+			want: true,
+		},
+		{
+			name: "and/or with parens",
+			expr: "Environment=prod AND (Owner=team-a OR Owner=team-b)",
+			tags: map[string]string{"Environment": "prod", "Owner": "team-b"},
+			want: true,
+		},
+		{
+			name: "and/or with parens, no match",
+			expr: "Environment=prod AND (Owner=team-a OR Owner=team-b)",
+			tags: map[string]string{"Environment": "prod", "Owner": "team-c"},
+			want: false,
+		},
+		{
+			name: "not",
+			expr: "NOT Environment=prod",
+			tags: map[string]string{"Environment": "staging"},
+			want: true,
+		},
+		{
+			name: "glob match",
+			expr: "CostCenter!=X-*",
+			tags: map[string]string{"CostCenter": "X-123"},
+			want: false,
+		},
+		{
+			name: "glob mismatch",
+			expr: "CostCenter!=X-*",
+			tags: map[string]string{"CostCenter": "Y-123"},
+			want: true,
+		},
+		{
+			name: "numeric comparison",
+			expr: "Rank<10",
+			tags: map[string]string{"Rank": "9"},
+			want: true,
+		},
+		{
+			name: "numeric comparison, string fallback",
+			expr: "Name<banana",
+			tags: map[string]string{"Name": "apple"},
+			want: true,
+		},
+		{
+			name: "in list",
+			expr: "Owner in [team-a,team-b,team-c]",
+			tags: map[string]string{"Owner": "team-b"},
+			want: true,
+		},
+		{
+			name: "in list, no match",
+			expr: "Owner in [team-a,team-b]",
+			tags: map[string]string{"Owner": "team-z"},
+			want: false,
+		},
+		{
+			name: "missing tag treated as empty string for equality",
+			expr: "Owner!=team-a",
+			tags: map[string]string{},
+			want: true,
+		},
+		{
+			name: "missing tag is unsatisfied for numeric comparison",
+			expr: "Rank<10",
+			tags: map[string]string{},
+			want: false,
+		},
+		{
+			name: "tag key that collides with a boolean keyword",
+			expr: "and=prod AND not!=team-a",
+			tags: map[string]string{"and": "prod", "not": "team-b"},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseTagExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseTagExpression(%q): %v", tt.expr, err)
+			}
+			if got := expr.Eval(tt.tags); got != tt.want {
+				t.Fatalf("Eval(%q) against %v = %v, want %v", tt.expr, tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTagExpressionErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"Environment=prod AND",
+		"(Environment=prod",
+		"Environment=prod)",
+		"Environment ? prod",
+		"Owner in [team-a, team-b",
+	}
+
+	for _, expr := range tests {
+		if _, err := ParseTagExpression(expr); err == nil {
+			t.Fatalf("ParseTagExpression(%q): expected error, got none", expr)
+		}
+	}
+}
+
+func TestFilterAccountsByTagExpression(t *testing.T) {
+	accounts := []AccountInfo{
+		{ID: "1", Tags: map[string]string{"Environment": "prod"}},
+		{ID: "2", Tags: map[string]string{"Environment": "staging"}},
+	}
+
+	expr, err := ParseTagExpression("Environment=prod")
+	if err != nil {
+		t.Fatalf("ParseTagExpression: %v", err)
+	}
+
+	got := filterAccountsByTagExpression(accounts, expr)
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Fatalf("filterAccountsByTagExpression = %+v, want only account 1", got)
+	}
+}